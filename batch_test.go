@@ -0,0 +1,87 @@
+package schoco_test
+
+import (
+	"testing"
+
+	"go.dedis.ch/kyber/v3"
+
+	"github.com/hpe-usp-spire/schoco"
+)
+
+func buildVerifyInput(t *testing.T, depth int) schoco.VerifyInput {
+	t.Helper()
+
+	sk, pk := schoco.KeyPair()
+
+	var msgs []string
+	for i := 0; i < depth; i++ {
+		msgs = append(msgs, message1)
+	}
+
+	aggSig := schoco.StdSign(msgs[0], sk)
+	aggMsgs := []string{msgs[0]}
+	var aggPartSigs []kyber.Point
+	for i := 1; i < len(msgs); i++ {
+		partSig, newSig := schoco.Aggregate(msgs[i], aggSig)
+		aggSig = newSig
+		aggPartSigs = append([]kyber.Point{partSig}, aggPartSigs...)
+		aggMsgs = append([]string{msgs[i]}, aggMsgs...)
+	}
+
+	return schoco.VerifyInput{
+		RootPubKey: pk,
+		Msgs:       aggMsgs,
+		PartSigs:   aggPartSigs,
+		Last:       aggSig,
+	}
+}
+
+func TestBatchVerifyAccepts(t *testing.T) {
+	items := []schoco.VerifyInput{
+		buildVerifyInput(t, 1),
+		buildVerifyInput(t, 3),
+		buildVerifyInput(t, 7),
+	}
+
+	ok, badIdx := schoco.BatchVerify(items)
+	if !ok {
+		t.Error("BatchVerify rejected a batch of valid tokens")
+	}
+	if len(badIdx) != 0 {
+		t.Errorf("BatchVerify reported bad indices %v for an all-valid batch", badIdx)
+	}
+}
+
+func TestBatchVerifyDetectsBadToken(t *testing.T) {
+	items := []schoco.VerifyInput{
+		buildVerifyInput(t, 2),
+		buildVerifyInput(t, 4),
+		buildVerifyInput(t, 2),
+	}
+	items[1].Last.S = items[0].Last.S
+
+	ok, badIdx := schoco.BatchVerify(items)
+	if ok {
+		t.Fatal("BatchVerify accepted a batch containing a tampered token")
+	}
+	if len(badIdx) != 1 || badIdx[0] != 1 {
+		t.Errorf("BatchVerify badIdx = %v, want [1]", badIdx)
+	}
+}
+
+func TestBatchVerifySingleStdSignToken(t *testing.T) {
+	sk, pk := schoco.KeyPair()
+	signature := schoco.StdSign(message1, sk)
+
+	items := []schoco.VerifyInput{{
+		RootPubKey: pk,
+		Msgs:       []string{message1},
+		PartSigs:   []kyber.Point{},
+		Last:       signature,
+	}}
+
+	ok, badIdx := schoco.BatchVerify(items)
+	if !ok || len(badIdx) != 0 {
+		t.Error("BatchVerify failed on a single std-signed token")
+	}
+}
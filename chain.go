@@ -0,0 +1,240 @@
+package schoco
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"go.dedis.ch/kyber/v3"
+)
+
+// chainMagic identifies the wire format Chain.MarshalBinary produces.
+var chainMagic = [4]byte{'S', 'C', 'H', 'O'}
+
+// ChainVersion1 is the only Chain wire format version schoco currently emits.
+const ChainVersion1 uint8 = 1
+
+// Chain is a self-describing wire format for an entire schoco-signed
+// chain, so callers don't have to marshal the root, messages and partial
+// signatures by hand the way ToByte/PointToByte require today. Msgs and
+// PartSigs are stored oldest-first (Msgs[0] is the message StdSign
+// signed, PartSigs[0] is that hop's partial signature) - the order a
+// verifier naturally walks in - whereas Verify takes them newest-first;
+// use ToVerifyArgs to convert.
+type Chain struct {
+	Version  uint8
+	Root     kyber.Point
+	Msgs     []string
+	PartSigs []kyber.Point
+	Last     Signature
+}
+
+// ToVerifyArgs reorders a Chain's fields into Verify's newest-first
+// convention.
+func (c Chain) ToVerifyArgs() (setMessages []string, setPartSig []kyber.Point) {
+	setMessages = make([]string, len(c.Msgs))
+	for i, m := range c.Msgs {
+		setMessages[len(c.Msgs)-1-i] = m
+	}
+	setPartSig = make([]kyber.Point, len(c.PartSigs))
+	for i, p := range c.PartSigs {
+		setPartSig[len(c.PartSigs)-1-i] = p
+	}
+	return setMessages, setPartSig
+}
+
+// Verify checks the chain against its own Root using Default().
+func (c Chain) Verify() bool {
+	setMessages, setPartSig := c.ToVerifyArgs()
+	return Default().Verify(c.Root, setMessages, setPartSig, c.Last)
+}
+
+// MarshalBinary encodes c as: magic "SCHO", a 1-byte version, the root
+// point, a varint hop count, then for each hop a varint-length message
+// followed by that hop's partial-sig point, the final message, and
+// finally the last signature's R‖S pair.
+func (c Chain) MarshalBinary() ([]byte, error) {
+	if len(c.PartSigs) != len(c.Msgs)-1 {
+		return nil, errors.New("schoco: Chain.PartSigs must have len(Msgs)-1 entries")
+	}
+
+	rootBytes, err := c.Root.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	lastBytes, err := c.Last.ToByte()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(chainMagic[:])
+	buf.WriteByte(c.Version)
+	buf.Write(rootBytes)
+	writeUvarint(&buf, uint64(len(c.PartSigs)))
+
+	for i, partSig := range c.PartSigs {
+		writeUvarint(&buf, uint64(len(c.Msgs[i])))
+		buf.WriteString(c.Msgs[i])
+
+		sigBytes, err := partSig.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(sigBytes)
+	}
+
+	lastMsg := c.Msgs[len(c.PartSigs)]
+	writeUvarint(&buf, uint64(len(lastMsg)))
+	buf.WriteString(lastMsg)
+	buf.Write(lastBytes)
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a Chain produced by MarshalBinary, reconstructing
+// points against Default()'s group.
+func (c *Chain) UnmarshalBinary(data []byte) error {
+	return Default().UnmarshalChain(data, c)
+}
+
+// UnmarshalChain decodes a Chain produced by MarshalBinary, reconstructing
+// points against s's group - use this instead of Chain.UnmarshalBinary for
+// a non-default Suite.
+func (s *Suite) UnmarshalChain(data []byte, c *Chain) error {
+	r := bytes.NewReader(data)
+
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return err
+	}
+	if magic != chainMagic {
+		return errors.New("schoco: not a schoco Chain (bad magic)")
+	}
+
+	version, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	rootBytes := make([]byte, s.group.PointLen())
+	if _, err := io.ReadFull(r, rootBytes); err != nil {
+		return err
+	}
+	root, err := s.ByteToPoint(rootBytes)
+	if err != nil {
+		return err
+	}
+
+	hopCount, err := binary.ReadUvarint(r)
+	if err != nil {
+		return err
+	}
+
+	msgs := make([]string, 0, hopCount+1)
+	partSigs := make([]kyber.Point, 0, hopCount)
+
+	for i := uint64(0); i < hopCount; i++ {
+		msg, err := readString(r)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+
+		sigBytes := make([]byte, s.group.PointLen())
+		if _, err := io.ReadFull(r, sigBytes); err != nil {
+			return err
+		}
+		partSig, err := s.ByteToPoint(sigBytes)
+		if err != nil {
+			return err
+		}
+		partSigs = append(partSigs, partSig)
+	}
+
+	lastMsg, err := readString(r)
+	if err != nil {
+		return err
+	}
+	msgs = append(msgs, lastMsg)
+
+	lastBytes := make([]byte, s.group.PointLen()+s.group.ScalarLen())
+	if _, err := io.ReadFull(r, lastBytes); err != nil {
+		return err
+	}
+	last, err := s.ByteToSignature(lastBytes)
+	if err != nil {
+		return err
+	}
+
+	c.Version = version
+	c.Root = root
+	c.Msgs = msgs
+	c.PartSigs = partSigs
+	c.Last = last
+	return nil
+}
+
+func writeUvarint(buf *bytes.Buffer, v uint64) {
+	var lenBytes [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBytes[:], v)
+	buf.Write(lenBytes[:n])
+}
+
+func readString(r *bytes.Reader) (string, error) {
+	msgLen, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", err
+	}
+	msgBytes := make([]byte, msgLen)
+	if _, err := io.ReadFull(r, msgBytes); err != nil {
+		return "", err
+	}
+	return string(msgBytes), nil
+}
+
+// StreamVerifier walks a chain's recurrence hop-by-hop in forward
+// (oldest-first) order, the same arithmetic Verify performs but without
+// needing every message and partial signature buffered and reversed up
+// front - useful for chains arriving incrementally over a network
+// connection, including long delegation paths such as the chains of up
+// to 36 hops exercised in TestCompareAggregation.
+type StreamVerifier struct {
+	suite *Suite
+	y     kyber.Point
+	hops  int
+}
+
+// NewStreamVerifier starts a streaming verification rooted at root.
+func (s *Suite) NewStreamVerifier(root kyber.Point) *StreamVerifier {
+	return &StreamVerifier{suite: s, y: root}
+}
+
+// NewStreamVerifier is Default().NewStreamVerifier; see Suite.NewStreamVerifier.
+func NewStreamVerifier(root kyber.Point) *StreamVerifier {
+	return Default().NewStreamVerifier(root)
+}
+
+// Push folds in the next hop: msg is the message that hop signed, and
+// partSig is that hop's partial signature (i.e. Signature.R). Hops must
+// be pushed in the order they were created (oldest first).
+func (v *StreamVerifier) Push(msg string, partSig kyber.Point) error {
+	if partSig == nil {
+		return errors.New("schoco: nil partial signature")
+	}
+
+	h := v.suite.challenge(dst, partSig, msg, v.y)
+	v.y = v.suite.group.Point().Sub(partSig, v.suite.group.Point().Mul(h, v.y))
+	v.hops++
+	return nil
+}
+
+// Finish checks the chain's final (msg, last) pair against the
+// accumulated public key and reports whether the whole chain verifies.
+func (v *StreamVerifier) Finish(msg string, last Signature) bool {
+	h := v.suite.challenge(dst, last.R, msg, v.y)
+	leftside := v.suite.group.Point().Mul(last.S, v.suite.base)
+	rightside := v.suite.group.Point().Sub(last.R, v.suite.group.Point().Mul(h, v.y))
+	return leftside.Equal(rightside)
+}
@@ -0,0 +1,145 @@
+package schoco_test
+
+import (
+	"testing"
+
+	"go.dedis.ch/kyber/v3"
+
+	"github.com/hpe-usp-spire/schoco"
+)
+
+func buildChain(t *testing.T) schoco.Chain {
+	t.Helper()
+
+	signature1 := schoco.StdSign(message1, rootSecretKey)
+	partsig1, signature2 := schoco.Aggregate(message2, signature1)
+	partsig2, signature3 := schoco.Aggregate(message3, signature2)
+
+	return schoco.Chain{
+		Version:  schoco.ChainVersion1,
+		Root:     rootPublicKey,
+		Msgs:     []string{message1, message2, message3},
+		PartSigs: []kyber.Point{partsig1, partsig2},
+		Last:     signature3,
+	}
+}
+
+func buildChainForSuite(t *testing.T, s *schoco.Suite) schoco.Chain {
+	t.Helper()
+
+	sk, pk := s.KeyPair()
+	signature1 := s.StdSign(message1, sk)
+	partsig1, signature2 := s.Aggregate(message2, signature1)
+	partsig2, signature3 := s.Aggregate(message3, signature2)
+
+	return schoco.Chain{
+		Version:  schoco.ChainVersion1,
+		Root:     pk,
+		Msgs:     []string{message1, message2, message3},
+		PartSigs: []kyber.Point{partsig1, partsig2},
+		Last:     signature3,
+	}
+}
+
+// TestChainMarshalRoundTripNonDefaultSuite guards against UnmarshalChain
+// inheriting ByteToSignature's PointLen()-vs-ScalarLen() assumptions: on
+// BN256G1 the two lengths differ, unlike Ed25519 where they match.
+func TestChainMarshalRoundTripNonDefaultSuite(t *testing.T) {
+	s := schoco.BN256G1()
+	chain := buildChainForSuite(t, s)
+
+	data, err := chain.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	var decoded schoco.Chain
+	if err := s.UnmarshalChain(data, &decoded); err != nil {
+		t.Fatalf("UnmarshalChain failed: %v", err)
+	}
+
+	setMessages, setPartSig := decoded.ToVerifyArgs()
+	if !s.Verify(decoded.Root, setMessages, setPartSig, decoded.Last) {
+		t.Error("decoded BN256G1 chain failed to verify")
+	}
+}
+
+func TestChainMarshalRoundTrip(t *testing.T) {
+	chain := buildChain(t)
+
+	data, err := chain.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	var decoded schoco.Chain
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+
+	if !decoded.Verify() {
+		t.Error("decoded chain failed to verify")
+	}
+}
+
+func TestChainMarshalRejectsMismatchedLengths(t *testing.T) {
+	chain := buildChain(t)
+	chain.PartSigs = chain.PartSigs[:1]
+
+	if _, err := chain.MarshalBinary(); err == nil {
+		t.Error("expected MarshalBinary to reject len(PartSigs) != len(Msgs)-1")
+	}
+}
+
+func TestChainUnmarshalRejectsBadMagic(t *testing.T) {
+	chain := buildChain(t)
+	data, err := chain.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	data[0] ^= 0xff
+
+	var decoded schoco.Chain
+	if err := decoded.UnmarshalBinary(data); err == nil {
+		t.Error("expected UnmarshalBinary to reject a corrupted magic prefix")
+	}
+}
+
+func TestStreamVerifier(t *testing.T) {
+	chain := buildChain(t)
+
+	v := schoco.NewStreamVerifier(chain.Root)
+	for i, partSig := range chain.PartSigs {
+		if err := v.Push(chain.Msgs[i], partSig); err != nil {
+			t.Fatalf("Push failed: %v", err)
+		}
+	}
+
+	if !v.Finish(chain.Msgs[len(chain.Msgs)-1], chain.Last) {
+		t.Error("StreamVerifier.Finish failed on a valid chain")
+	}
+}
+
+func TestStreamVerifierRejectsTamperedMessage(t *testing.T) {
+	chain := buildChain(t)
+
+	v := schoco.NewStreamVerifier(chain.Root)
+	for i, partSig := range chain.PartSigs {
+		if err := v.Push(chain.Msgs[i], partSig); err != nil {
+			t.Fatalf("Push failed: %v", err)
+		}
+	}
+
+	if v.Finish("tampered", chain.Last) {
+		t.Error("StreamVerifier.Finish accepted a tampered final message")
+	}
+}
+
+func TestStreamVerifierMatchesStdSign(t *testing.T) {
+	signature := schoco.StdSign(message1, rootSecretKey)
+
+	v := schoco.NewStreamVerifier(rootPublicKey)
+	if !v.Finish(message1, signature) {
+		t.Error("StreamVerifier with no hops should match StdVerify")
+	}
+}
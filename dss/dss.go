@@ -0,0 +1,278 @@
+// Package dss implements threshold, distributed signing of a schoco root
+// signature: instead of a single KeyPair() holding the root secret, a
+// t-of-n committee jointly holds it and cooperates to produce the
+// Signature that seeds a schoco chain.
+//
+// The protocol follows the Provably-Secure Distributed Schnorr Signatures
+// (DSS) scheme used by ethdss (Chainlink/Wormhole):
+//  1. Run a Pedersen VSS/DKG once to obtain long-term shares of the root
+//     secret x, with shared public key Y = x*G (GenerateLongTermShares).
+//  2. For each signing session, run a second DKG to share a one-time
+//     nonce k, with public commitment R = k*G (NewSession).
+//  3. Each participant computes a partial signature
+//     s_i = k_i - H(R‖m‖Y)*x_i and broadcasts it (Sign).
+//  4. A combiner Lagrange-interpolates the s_i from any t valid partials
+//     to yield s, producing a normal schoco.Signature{R, S} that verifies
+//     exactly like a StdSign output (Combine).
+//
+// The critical invariant is that Y stays fixed across sessions while a
+// fresh k is drawn per signature, and that the challenge hash is computed
+// via schoco.StdChallenge - the same transcript StdSign and Verify use -
+// so schoco.Verify and schoco.Aggregate need no changes to consume the
+// result.
+package dss
+
+import (
+	"errors"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+	"go.dedis.ch/kyber/v3/share"
+	dkg "go.dedis.ch/kyber/v3/share/dkg/pedersen"
+
+	"github.com/hpe-usp-spire/schoco"
+)
+
+// Set parameters (the same curve as schoco.Default(), whose StdChallenge
+// Sign uses).
+var (
+	curve = edwards25519.NewBlakeSHA256Ed25519()
+	g     = curve.Point().Base()
+)
+
+// Participant is a single member of the t-of-n signing committee. It holds
+// the long-term share of the root secret and, once a session has been
+// started, the share of that session's one-time nonce.
+type Participant struct {
+	Index int
+
+	longTerm  *share.PriShare // x_i, share of the root secret x
+	PublicKey kyber.Point     // Y, the shared root public key (same for all participants)
+	thresh    int             // t, the reconstruction threshold fixed at DKG time
+
+	nonce *share.PriShare // k_i, share of the current session's nonce
+	R     kyber.Point     // R = k*G, the current session's public commitment
+}
+
+// PartialSig is one participant's contribution to a threshold signature.
+// It is network-serializable via Bytes/PartialSigFromBytes so transports
+// other than an in-process combiner can carry it.
+type PartialSig struct {
+	Index int
+	R     kyber.Point
+	S     kyber.Scalar
+}
+
+// Bytes encodes a PartialSig as index‖R‖S for transport.
+func (p PartialSig) Bytes() ([]byte, error) {
+	rBytes, err := p.R.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	sBytes, err := p.S.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 4, 4+len(rBytes)+len(sBytes))
+	out[0] = byte(p.Index >> 24)
+	out[1] = byte(p.Index >> 16)
+	out[2] = byte(p.Index >> 8)
+	out[3] = byte(p.Index)
+	out = append(out, rBytes...)
+	out = append(out, sBytes...)
+	return out, nil
+}
+
+// PartialSigFromBytes decodes a PartialSig produced by PartialSig.Bytes.
+func PartialSigFromBytes(data []byte) (PartialSig, error) {
+	if len(data) < 4 {
+		return PartialSig{}, errors.New("dss: partial sig too short")
+	}
+	index := int(data[0])<<24 | int(data[1])<<16 | int(data[2])<<8 | int(data[3])
+	rest := data[4:]
+
+	rLen := len(rest) / 2
+	if rLen*2 != len(rest) {
+		return PartialSig{}, errors.New("dss: invalid partial sig length")
+	}
+
+	r := curve.Point().Null()
+	if err := r.UnmarshalBinary(rest[:rLen]); err != nil {
+		return PartialSig{}, err
+	}
+	s := curve.Scalar().SetBytes(rest[rLen:])
+
+	return PartialSig{Index: index, R: r, S: s}, nil
+}
+
+// GenerateLongTermShares runs a Pedersen DKG among n simulated
+// participants and returns their long-term shares of a freshly generated
+// root secret x, along with the shared root public key Y = x*G. t is the
+// reconstruction threshold.
+//
+// This is meant for tests and single-process setups; a real deployment
+// runs one DistKeyGenerator per participant across a network and feeds
+// deals/responses through a transport instead of exchanging them
+// in-process.
+func GenerateLongTermShares(n, t int) ([]*Participant, kyber.Point, error) {
+	longterms := make([]kyber.Scalar, n)
+	pubs := make([]kyber.Point, n)
+	for i := 0; i < n; i++ {
+		longterms[i] = curve.Scalar().Pick(curve.RandomStream())
+		pubs[i] = curve.Point().Mul(longterms[i], g)
+	}
+
+	shares, err := runDKG(longterms, pubs, t)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	participants := make([]*Participant, n)
+	var rootPublicKey kyber.Point
+	for i, dks := range shares {
+		if rootPublicKey == nil {
+			rootPublicKey = dks.Public()
+		}
+		participants[i] = &Participant{
+			Index:     i,
+			longTerm:  dks.PriShare(),
+			PublicKey: rootPublicKey,
+			thresh:    t,
+		}
+	}
+
+	return participants, rootPublicKey, nil
+}
+
+// NewSession runs a second, independent DKG among the same committee to
+// share a one-time nonce k, and records the resulting share and public
+// commitment R = k*G on each Participant. Call this once per signature to
+// be produced.
+//
+// The nonce is shared at the same threshold t used for the long-term
+// secret (set by GenerateLongTermShares), not at n: s(x) = k(x) - h*x(x)
+// only has degree t-1 if both k(x) and x(x) do, so any t of the s_i
+// Lagrange-interpolate to the correct s. Sharing k at n instead would
+// make signing require all n participants.
+func NewSession(participants []*Participant) (kyber.Point, error) {
+	n := len(participants)
+	t := participants[0].thresh
+	longterms := make([]kyber.Scalar, n)
+	pubs := make([]kyber.Point, n)
+	for i := range participants {
+		longterms[i] = curve.Scalar().Pick(curve.RandomStream())
+		pubs[i] = curve.Point().Mul(longterms[i], g)
+	}
+
+	shares, err := runDKG(longterms, pubs, t)
+	if err != nil {
+		return nil, err
+	}
+
+	var r kyber.Point
+	for i, dks := range shares {
+		if r == nil {
+			r = dks.Public()
+		}
+		participants[i].nonce = dks.PriShare()
+		participants[i].R = r
+	}
+
+	return r, nil
+}
+
+// Sign computes participant i's partial signature over m for the current
+// session: s_i = k_i - H(R‖m‖Y)*x_i. NewSession must have been called
+// first to populate the participant's nonce share.
+func (p *Participant) Sign(m string) (PartialSig, error) {
+	if p.nonce == nil || p.R == nil {
+		return PartialSig{}, errors.New("dss: no active session; call NewSession first")
+	}
+
+	h := schoco.StdChallenge(p.R, m, p.PublicKey)
+
+	s := curve.Scalar().Sub(p.nonce.V, curve.Scalar().Mul(h, p.longTerm.V))
+
+	return PartialSig{Index: p.Index, R: p.R, S: s}, nil
+}
+
+// Combine Lagrange-interpolates s from t (or more) valid partial
+// signatures sharing the same R, yielding a normal schoco.Signature that
+// verifies against Y exactly like a StdSign output.
+func Combine(partials []PartialSig, t int) (schoco.Signature, error) {
+	if len(partials) < t {
+		return schoco.Signature{}, errors.New("dss: not enough partial signatures")
+	}
+
+	r := partials[0].R
+	priShares := make([]*share.PriShare, len(partials))
+	for i, p := range partials {
+		if !p.R.Equal(r) {
+			return schoco.Signature{}, errors.New("dss: partial signatures disagree on R")
+		}
+		priShares[i] = &share.PriShare{I: p.Index, V: p.S}
+	}
+
+	s, err := share.RecoverSecret(curve, priShares, t, len(partials))
+	if err != nil {
+		return schoco.Signature{}, err
+	}
+
+	return schoco.Signature{R: r, S: s}, nil
+}
+
+// runDKG drives a full Pedersen DKG round (deals, responses, key
+// extraction) among len(longterms) simulated participants and returns
+// each one's resulting DistKeyShare.
+func runDKG(longterms []kyber.Scalar, pubs []kyber.Point, t int) ([]*dkg.DistKeyShare, error) {
+	n := len(longterms)
+	generators := make([]*dkg.DistKeyGenerator, n)
+	for i := range generators {
+		gen, err := dkg.NewDistKeyGenerator(curve, longterms[i], pubs, t)
+		if err != nil {
+			return nil, err
+		}
+		generators[i] = gen
+	}
+
+	responses := make([]*dkg.Response, 0, n*(n-1))
+	for _, gen := range generators {
+		deals, err := gen.Deals()
+		if err != nil {
+			return nil, err
+		}
+		for j, deal := range deals {
+			resp, err := generators[j].ProcessDeal(deal)
+			if err != nil {
+				return nil, err
+			}
+			responses = append(responses, resp)
+		}
+	}
+
+	for _, resp := range responses {
+		for i, gen := range generators {
+			if int(resp.Response.Index) == i {
+				continue
+			}
+			if _, err := gen.ProcessResponse(resp); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	shares := make([]*dkg.DistKeyShare, n)
+	for i, gen := range generators {
+		if !gen.Certified() {
+			return nil, errors.New("dss: DKG did not certify")
+		}
+		dks, err := gen.DistKeyShare()
+		if err != nil {
+			return nil, err
+		}
+		shares[i] = dks
+	}
+
+	return shares, nil
+}
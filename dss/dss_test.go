@@ -0,0 +1,63 @@
+package dss_test
+
+import (
+	"testing"
+
+	"go.dedis.ch/kyber/v3"
+
+	"github.com/hpe-usp-spire/schoco"
+	"github.com/hpe-usp-spire/schoco/dss"
+)
+
+func TestThresholdRootSign(t *testing.T) {
+	const n = 5
+	const thresh = 3
+
+	participants, rootPublicKey, err := dss.GenerateLongTermShares(n, thresh)
+	if err != nil {
+		t.Fatalf("GenerateLongTermShares failed: %v", err)
+	}
+
+	if _, err := dss.NewSession(participants); err != nil {
+		t.Fatalf("NewSession failed: %v", err)
+	}
+
+	message1 := "first message"
+	partials := make([]dss.PartialSig, 0, n)
+	for _, p := range participants[:thresh] {
+		partial, err := p.Sign(message1)
+		if err != nil {
+			t.Fatalf("participant %d failed to sign: %v", p.Index, err)
+		}
+		partials = append(partials, partial)
+	}
+
+	rootSig, err := dss.Combine(partials, thresh)
+	if err != nil {
+		t.Fatalf("Combine failed: %v", err)
+	}
+
+	if !schoco.StdVerify(message1, rootSig, rootPublicKey) {
+		t.Fatal("threshold-signed root signature did not verify")
+	}
+
+	t.Run("root signature seeds a multi-hop schoco chain", func(t *testing.T) {
+		message2 := "second message"
+		partSig1, sig2 := schoco.Aggregate(message2, rootSig)
+
+		setPartSig := []kyber.Point{partSig1}
+		setMsg := []string{message2, message1}
+		if !schoco.Verify(rootPublicKey, setMsg, setPartSig, sig2) {
+			t.Error("schoco.Verify failed for chain rooted in a threshold signature")
+		}
+
+		message3 := "third message"
+		partSig2, sig3 := schoco.Aggregate(message3, sig2)
+
+		setPartSig = []kyber.Point{partSig2, partSig1}
+		setMsg = []string{message3, message2, message1}
+		if !schoco.Verify(rootPublicKey, setMsg, setPartSig, sig3) {
+			t.Error("schoco.Verify failed for a two-hop chain rooted in a threshold signature")
+		}
+	})
+}
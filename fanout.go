@@ -0,0 +1,174 @@
+package schoco
+
+import (
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/pairing/bn256"
+	"go.dedis.ch/kyber/v3/sign"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+// dstBDNCoef domain-separates the per-signer binding coefficients from
+// every other hash this package computes.
+const dstBDNCoef = "schoco/bdn-coef/v1"
+
+// maskSuite is a throwaway pairing.Suite used only to satisfy
+// sign.NewMask's constructor signature; NewMask never actually reads from
+// it, and a *sign.Mask otherwise carries points from whatever group the
+// caller built it with, so this lets AggregateMulti build masks over
+// plain Ed25519/BN256G1 Suite points without schoco.Suite itself having
+// to be pairing-capable.
+var maskSuite = bn256.NewSuiteG1()
+
+// newParticipantMask builds a mask over publics with every bit set,
+// recording that all of them co-signed the hop they were built for.
+func newParticipantMask(publics []kyber.Point) (*sign.Mask, error) {
+	mask, err := sign.NewMask(maskSuite, publics, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range publics {
+		if err := mask.SetBit(i, true); err != nil {
+			return nil, err
+		}
+	}
+	return mask, nil
+}
+
+// bdnCoefficients derives one binding coefficient per entry of publics,
+// the same rogue-key defense kyber's sign/bdn uses when aggregating BLS
+// public keys: each c_i is a hash of the full public-key list together
+// with i, so nobody can choose their own public key after the fact to
+// cancel out everyone else's and steer the combined key to a chosen
+// target - doing so would mean inverting the hash. Plain unweighted
+// summation (Y_hop = Σ Y_i) does not have this property, since an
+// attacker who registers last can always publish Y_n = target - Σ Y_i.
+func (s *Suite) bdnCoefficients(publics []kyber.Point) []kyber.Scalar {
+	transcript := s.newHash()
+	writeLenPrefixed(transcript, []byte(dstBDNCoef))
+	for _, p := range publics {
+		writeLenPrefixed(transcript, mustMarshalBinary(p))
+	}
+	base := transcript.Sum(nil)
+
+	coefs := make([]kyber.Scalar, len(publics))
+	for i := range publics {
+		h := s.newHash()
+		h.Write(base)
+		writeLenPrefixed(h, []byte{byte(i >> 24), byte(i >> 16), byte(i >> 8), byte(i)})
+		coefs[i] = s.group.Scalar().SetBytes(h.Sum(nil))
+	}
+	return coefs
+}
+
+// aggregatedPublicKey reconstructs Y_agg = Σ_{i∈mask} c_i*Y_i, the
+// coefficient-weighted combined public key of every delegate the mask
+// records as having participated; see bdnCoefficients. It returns the
+// group's identity point when no bit is set.
+func (s *Suite) aggregatedPublicKey(mask *sign.Mask) kyber.Point {
+	publics := mask.Publics()
+	coefs := s.bdnCoefficients(publics)
+
+	sum := s.group.Point().Null()
+	enabled := mask.CountEnabled()
+	for n := 0; n < enabled; n++ {
+		idx := mask.IndexOfNthEnabled(n)
+		if idx < 0 {
+			panic("schoco: mask reports fewer enabled bits than CountEnabled")
+		}
+		sum = s.group.Point().Add(sum, s.group.Point().Mul(coefs[idx], publics[idx]))
+	}
+	return sum
+}
+
+// combineHopPublicKey computes Y_hop = Σ_{i∈mask} c_i*Y_i + n*aggKeyPublic
+// for a fan-out hop, where mask records the co-signers' base identities
+// (signerKeys*G) weighted by their bdnCoefficients, n is the number of
+// co-signers, and aggKeyPublic is aggKey*G for the aggKey every
+// co-signer's effective hop secret was shifted by. AggregateMulti and
+// resolveHopPublicKey both need this, the former to produce Y_hop and
+// the latter to reconstruct it.
+func (s *Suite) combineHopPublicKey(mask *sign.Mask, aggKeyPublic kyber.Point) kyber.Point {
+	n := s.group.Scalar().SetInt64(int64(mask.CountEnabled()))
+	return s.group.Point().Add(s.aggregatedPublicKey(mask), s.group.Point().Mul(n, aggKeyPublic))
+}
+
+// resolveHopPublicKey returns the public key the recurrence should use
+// for the hop at setMessages index idx. If masks carries no entry (or a
+// nil entry) there, that hop was signed by a single delegate the
+// ordinary way and y - the recurrence's own running value - is returned
+// unchanged. Otherwise the hop was a fan-out hop produced by
+// AggregateMulti, and y is exactly aggKey*G at this point in the
+// recurrence, so combineHopPublicKey(masks[idx], y) reconstructs Y_hop.
+func (s *Suite) resolveHopPublicKey(masks []*sign.Mask, idx int, y kyber.Point) kyber.Point {
+	if idx < 0 || idx >= len(masks) || masks[idx] == nil {
+		return y
+	}
+	return s.combineHopPublicKey(masks[idx], y)
+}
+
+// AggregateMulti extends Aggregate to a hop co-signed by multiple
+// delegates in parallel - a fan-out hop - rather than a single signer,
+// modelled on the mask-based multi-signature aggregation in kyber's
+// sign/bdn (adapted here to schoco's Schnorr recurrence instead of bdn's
+// BLS pairing, including bdn's per-signer binding coefficients, which
+// bdnCoefficients computes, to rule out rogue-key attacks against the
+// combined public key). As in Aggregate, the chain's aggKey is extracted
+// from prevSig; each signerKey's effective secret for this hop is
+// c_i*signerKey+aggKey, so every delegate's hop identity is bound to the
+// chain via the same aggKey offset while keeping its own long-term
+// identity distinct and coefficient-weighted. Each delegate independently
+// draws a nonce k_i and commitment R_i=k_i*G; the combiner sums R=ΣR_i
+// and, using the single challenge h=Hash(R, m, Y_hop) over the combined
+// commitment and the combined public key Y_hop=Σc_i*Y_i+n*aggKey*G (see
+// combineHopPublicKey), sums s=Σs_i with
+// s_i=k_i-h*(c_i*signerKey_i+aggKey). The returned mask records Y_hop's
+// participants (the base identities signerKeys*G, not the hop-shifted
+// ones) so Verify can rebuild Y_hop itself, coefficients and all, from
+// the recurrence's own y rather than trusting a precomputed point. With
+// a single signer, len(signerKeys)==1, the mask has exactly one bit set,
+// but the hop is not an Aggregate hop: its key still includes
+// c_0*signerKey*G, so Verify accepts it only when given that mask.
+func (s *Suite) AggregateMulti(m string, prevSig Signature, signerKeys []kyber.Scalar) (kyber.Point, *sign.Mask, Signature) {
+	if len(signerKeys) == 0 {
+		panic("schoco: AggregateMulti requires at least one signer")
+	}
+
+	aggKey, partSig1 := prevSig.ExtractAggKey()
+
+	n := len(signerKeys)
+	nonces := make([]kyber.Scalar, n)
+	basePublics := make([]kyber.Point, n)
+	for i, signerKey := range signerKeys {
+		nonces[i] = s.group.Scalar().Pick(random.New())
+		basePublics[i] = s.group.Point().Mul(signerKey, s.base)
+	}
+
+	mask, err := newParticipantMask(basePublics)
+	if err != nil {
+		panic("schoco: failed to build participant mask: " + err.Error())
+	}
+	coefs := s.bdnCoefficients(basePublics)
+
+	rSum := s.group.Point().Null()
+	for _, k := range nonces {
+		rSum = s.group.Point().Add(rSum, s.group.Point().Mul(k, s.base))
+	}
+
+	aggKeyPublic := s.group.Point().Mul(aggKey, s.base)
+	yHop := s.combineHopPublicKey(mask, aggKeyPublic)
+
+	h := s.challenge(dst, rSum, m, yHop)
+
+	sSum := s.group.Scalar().Zero()
+	for i, signerKey := range signerKeys {
+		hopSecret := s.group.Scalar().Add(s.group.Scalar().Mul(coefs[i], signerKey), aggKey)
+		sSum = s.group.Scalar().Add(sSum, s.group.Scalar().Sub(nonces[i], s.group.Scalar().Mul(h, hopSecret)))
+	}
+
+	return partSig1, mask, Signature{R: rSum, S: sSum}
+}
+
+// AggregateMulti is Default().AggregateMulti; see Suite.AggregateMulti.
+func AggregateMulti(m string, prevSig Signature, signerKeys []kyber.Scalar) (kyber.Point, *sign.Mask, Signature) {
+	return Default().AggregateMulti(m, prevSig, signerKeys)
+}
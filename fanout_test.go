@@ -0,0 +1,142 @@
+package schoco_test
+
+import (
+	"testing"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/pairing/bn256"
+	"go.dedis.ch/kyber/v3/sign"
+
+	"github.com/hpe-usp-spire/schoco"
+)
+
+func TestAggregateMultiVerifiesFanOutHop(t *testing.T) {
+	signature1 := schoco.StdSign(message1, rootSecretKey)
+
+	delegate2, _ := schoco.KeyPair()
+	delegate3, _ := schoco.KeyPair()
+
+	partSig1, mask, signature2 := schoco.AggregateMulti(message2, signature1, []kyber.Scalar{delegate2, delegate3})
+
+	setSigR := []kyber.Point{partSig1}
+	setMsg := []string{message2, message1}
+	if !schoco.Verify(rootPublicKey, setMsg, setSigR, signature2, mask) {
+		t.Error("fan-out hop failed to verify against its mask")
+	}
+}
+
+func TestAggregateMultiRequiresTheMask(t *testing.T) {
+	signature1 := schoco.StdSign(message1, rootSecretKey)
+
+	delegate2, _ := schoco.KeyPair()
+	delegate3, _ := schoco.KeyPair()
+
+	partSig1, _, signature2 := schoco.AggregateMulti(message2, signature1, []kyber.Scalar{delegate2, delegate3})
+
+	setSigR := []kyber.Point{partSig1}
+	setMsg := []string{message2, message1}
+	if schoco.Verify(rootPublicKey, setMsg, setSigR, signature2) {
+		t.Error("Verify accepted a fan-out hop without its mask")
+	}
+}
+
+func TestAggregateMultiSingleSignerVerifiesWithOneBitMask(t *testing.T) {
+	signature1 := schoco.StdSign(message1, rootSecretKey)
+
+	delegate2, _ := schoco.KeyPair()
+	partSig1, mask, signature2 := schoco.AggregateMulti(message2, signature1, []kyber.Scalar{delegate2})
+
+	if mask.CountEnabled() != 1 {
+		t.Errorf("expected a single bit set for one signer, got %d", mask.CountEnabled())
+	}
+
+	setSigR := []kyber.Point{partSig1}
+	setMsg := []string{message2, message1}
+	if !schoco.Verify(rootPublicKey, setMsg, setSigR, signature2, mask) {
+		t.Error("single-delegate AggregateMulti hop failed to verify")
+	}
+}
+
+func TestAggregateMultiHopFollowedByLinearHop(t *testing.T) {
+	signature1 := schoco.StdSign(message1, rootSecretKey)
+
+	delegate2, _ := schoco.KeyPair()
+	delegate3, _ := schoco.KeyPair()
+	partSig1, mask, signature2 := schoco.AggregateMulti(message2, signature1, []kyber.Scalar{delegate2, delegate3})
+
+	partSig2, signature3 := schoco.Aggregate(message3, signature2)
+
+	// masks is indexed like setMessages: masks[0] is for the lastsig
+	// (message3) hop, which was signed the ordinary linear way, so it is
+	// nil; masks[1] is for the message2 hop, the fan-out one.
+	setSigR := []kyber.Point{partSig2, partSig1}
+	setMsg := []string{message3, message2, message1}
+	if !schoco.Verify(rootPublicKey, setMsg, setSigR, signature3, nil, mask) {
+		t.Error("a fan-out hop followed by a linear hop failed to verify")
+	}
+}
+
+func TestAggregateMultiRejectsRogueKeySubstitution(t *testing.T) {
+	signature1 := schoco.StdSign(message1, rootSecretKey)
+
+	delegate2, _ := schoco.KeyPair()
+	delegate3, _ := schoco.KeyPair()
+
+	partSig1, mask, signature2 := schoco.AggregateMulti(message2, signature1, []kyber.Scalar{delegate2, delegate3})
+
+	// A forged mask that swaps in a third party's public key in place of
+	// a real co-signer must not verify: the combined key is
+	// coefficient-weighted against the full roster rather than summed
+	// plain, so substituting any one entry changes every coefficient
+	// rather than just dropping a term out of a linear sum.
+	_, rogueKeyPublic := schoco.KeyPair()
+	publics := mask.Publics()
+	publics[0] = rogueKeyPublic
+
+	forged, err := sign.NewMask(bn256.NewSuiteG1(), publics, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range publics {
+		if err := forged.SetBit(i, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	setSigR := []kyber.Point{partSig1}
+	setMsg := []string{message2, message1}
+	if schoco.Verify(rootPublicKey, setMsg, setSigR, signature2, forged) {
+		t.Error("Verify accepted a mask with a substituted public key")
+	}
+}
+
+func TestAggregateMultiRejectsEmptySignerSet(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected AggregateMulti to panic on an empty signer set")
+		}
+	}()
+
+	signature1 := schoco.StdSign(message1, rootSecretKey)
+	schoco.AggregateMulti(message2, signature1, nil)
+}
+
+func TestVerifyRejectsAllClearedMaskWithoutPanicking(t *testing.T) {
+	signature1 := schoco.StdSign(message1, rootSecretKey)
+
+	delegate2, _ := schoco.KeyPair()
+	delegate3, _ := schoco.KeyPair()
+	partSig1, mask, signature2 := schoco.AggregateMulti(message2, signature1, []kyber.Scalar{delegate2, delegate3})
+
+	publics := mask.Publics()
+	cleared, err := sign.NewMask(bn256.NewSuiteG1(), publics, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	setSigR := []kyber.Point{partSig1}
+	setMsg := []string{message2, message1}
+	if schoco.Verify(rootPublicKey, setMsg, setSigR, signature2, cleared) {
+		t.Error("Verify accepted a fan-out hop against an all-cleared mask")
+	}
+}
@@ -4,17 +4,17 @@ import (
 	"testing"
 
 	"github.com/hpe-usp-spire/schoco"
-	"go.dedis.ch/kyber/v3/group/edwards25519"
 	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
 )
 
 var (
-	curve = edwards25519.NewBlakeSHA256Ed25519()
+	curve         = edwards25519.NewBlakeSHA256Ed25519()
 	rootSecretKey = curve.Scalar().Pick(curve.RandomStream())
 	rootPublicKey = curve.Point().Mul(rootSecretKey, curve.Point().Base())
-	message1 = "first message"
-	message2 = "second message"
-	message3 = "third message"
+	message1      = "first message"
+	message2      = "second message"
+	message3      = "third message"
 )
 
 func TestBasic(t *testing.T) {
@@ -29,7 +29,7 @@ func TestBasic(t *testing.T) {
 		}
 	})
 
-	t.Run("Test schoco.Aggregate: ", func(t *testing.T) { 
+	t.Run("Test schoco.Aggregate: ", func(t *testing.T) {
 
 		// generate signature
 		signature1 := schoco.StdSign(message1, rootSecretKey)
@@ -42,13 +42,12 @@ func TestBasic(t *testing.T) {
 
 		// Aggregate signature1 with a new signature over message2
 		partsig1, signature2 := schoco.Aggregate(message2, signature1)
-		partsig1Bytes, signature2Bytes := schoco.NewAgg(message2, sig1Bytes)
-
+		partsig1Bytes, signature2Bytes := schoco.TestByteAgg(message2, sig1Bytes)
 
 		// validate concatenated signature
 		setSigR := []kyber.Point{partsig1}
 		setMsg := []string{message2, message1}
-		if !schoco.Verify(rootPublicKey, setMsg, setSigR, signature2)	{
+		if !schoco.Verify(rootPublicKey, setMsg, setSigR, signature2) {
 			t.Error("Validate schoco.Aggregate with schoco.Verify failed!")
 		}
 
@@ -60,8 +59,7 @@ func TestBasic(t *testing.T) {
 		}
 		setPartSigBytes := [][]byte{partsig1Bytes}
 
-
-		if !schoco.NewVerify(rootPublicKeyBytes, setMsg, setPartSigBytes, signature2Bytes)	{
+		if !schoco.TestByteVerify(rootPublicKeyBytes, setMsg, setPartSigBytes, signature2Bytes) {
 			t.Error("Validate schoco.Aggregate with schoco.Verify failed!")
 		}
 	})
@@ -81,25 +79,25 @@ func TestVerify(t *testing.T) {
 	// Use schoCo.Aggregate to aggregate a new signature
 	partsig2, signature3 := schoco.Aggregate(message3, signature2)
 
-	t.Run("Validate Std signature (signature1) with schoco.Verify: ", func(t *testing.T) { 
+	t.Run("Validate Std signature (signature1) with schoco.Verify: ", func(t *testing.T) {
 		setSigR := []kyber.Point{}
 		setMsg := []string{message1}
 
-		if !schoco.Verify(rootPublicKey, setMsg, setSigR, signature1)	{
+		if !schoco.Verify(rootPublicKey, setMsg, setSigR, signature1) {
 			t.Error("Validate Std signature with schoco.Verify failed!")
 		}
 	})
 
-	t.Run("Validate SchoCo signature with schoco.Verify: ", func(t *testing.T) { 
+	t.Run("Validate SchoCo signature with schoco.Verify: ", func(t *testing.T) {
 		setSigR := []kyber.Point{partSig}
 		setMsg := []string{message2, message1}
 
-		if !schoco.Verify(rootPublicKey, setMsg, setSigR, signature2)	{
+		if !schoco.Verify(rootPublicKey, setMsg, setSigR, signature2) {
 			t.Error("Validate SchoCo signature with schoco.Verify failed!")
 		}
 	})
 
-	t.Run("Validate signature2 with schoco.StdVerify: ", func(t *testing.T) { 
+	t.Run("Validate signature2 with schoco.StdVerify: ", func(t *testing.T) {
 
 		// Validate the signature using the agg public key
 		aggPK := curve.Point().Mul(aggKey, curve.Point().Base())
@@ -108,12 +106,95 @@ func TestVerify(t *testing.T) {
 		}
 	})
 
-	t.Run("Validate signature3 with schoco.Verify: ", func(t *testing.T) { 
+	t.Run("Validate signature3 with schoco.Verify: ", func(t *testing.T) {
 		setSigR := []kyber.Point{partsig2, partSig}
 		setMsg := []string{message3, message2, message1}
 
-		if !schoco.Verify(rootPublicKey, setMsg, setSigR, signature3)	{
+		if !schoco.Verify(rootPublicKey, setMsg, setSigR, signature3) {
 			t.Error("Validate SchoCo signature with schoco.Verify failed!")
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestVerifyAgainstID(t *testing.T) {
+	id := "spiffe://example.org/workload"
+	idSecretKey, _ := schoco.KeyPair(id)
+
+	signature1 := schoco.StdSign(message1, idSecretKey)
+	aggKey, partSig := signature1.ExtractAggKey()
+	signature2base := schoco.StdSign(message2, aggKey)
+	partsig2, signature3 := schoco.Aggregate(message3, signature2base)
+
+	setSigR := []kyber.Point{partsig2, partSig}
+	setMsg := []string{message3, message2, message1}
+
+	if !schoco.VerifyAgainstID(id, setMsg, setSigR, signature3) {
+		t.Error("VerifyAgainstID failed for a chain rooted in KeyPair(id)")
+	}
+
+	if schoco.VerifyAgainstID("spiffe://example.org/someone-else", setMsg, setSigR, signature3) {
+		t.Error("VerifyAgainstID succeeded against the wrong id")
+	}
+}
+
+func TestRecover(t *testing.T) {
+	// Recover cannot invert schoco's challenge hash (it binds the public
+	// key it is later multiplied against), so it always reports an error
+	// rather than a wrong or misleading public key.
+	_, err := schoco.Recover([]string{message1}, []kyber.Point{}, schoco.StdSign(message1, rootSecretKey))
+	if err == nil {
+		t.Error("expected Recover to report that the root key cannot be recovered")
+	}
+}
+
+func TestDomainSeparatedTranscript(t *testing.T) {
+	suite := schoco.Ed25519()
+	sk, pk := suite.KeyPair()
+
+	t.Run("default suite signs and verifies with the domain-separated transcript", func(t *testing.T) {
+		signature1 := suite.StdSign(message1, sk)
+		partsig1, signature2 := suite.Aggregate(message2, signature1)
+
+		setSigR := []kyber.Point{partsig1}
+		setMsg := []string{message2, message1}
+		if !suite.Verify(pk, setMsg, setSigR, signature2) {
+			t.Error("domain-separated chain failed to verify")
+		}
+	})
+
+	t.Run("a legacy-signed chain does not verify against the default (non-legacy) suite", func(t *testing.T) {
+		legacy := suite.Legacy()
+		signature1 := legacy.StdSign(message1, sk)
+		partsig1, signature2 := legacy.Aggregate(message2, signature1)
+
+		setSigR := []kyber.Point{partsig1}
+		setMsg := []string{message2, message1}
+		if suite.Verify(pk, setMsg, setSigR, signature2) {
+			t.Error("legacy transcript substituted into the default suite's hash must not verify")
+		}
+	})
+
+	t.Run("a default-signed chain does not verify against Legacy()", func(t *testing.T) {
+		legacy := suite.Legacy()
+		signature1 := suite.StdSign(message1, sk)
+		partsig1, signature2 := suite.Aggregate(message2, signature1)
+
+		setSigR := []kyber.Point{partsig1}
+		setMsg := []string{message2, message1}
+		if legacy.Verify(pk, setMsg, setSigR, signature2) {
+			t.Error("domain-separated transcript substituted into Legacy()'s hash must not verify")
+		}
+	})
+
+	t.Run("Legacy() alone still verifies chains it signed, end to end", func(t *testing.T) {
+		legacy := suite.Legacy()
+		signature1 := legacy.StdSign(message1, sk)
+		partsig1, signature2 := legacy.Aggregate(message2, signature1)
+
+		setSigR := []kyber.Point{partsig1}
+		setMsg := []string{message2, message1}
+		if !legacy.Verify(pk, setMsg, setSigR, signature2) {
+			t.Error("Legacy() chain should still verify against itself")
+		}
+	})
+}
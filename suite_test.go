@@ -0,0 +1,145 @@
+package schoco_test
+
+import (
+	"testing"
+
+	"go.dedis.ch/kyber/v3"
+
+	"github.com/hpe-usp-spire/schoco"
+)
+
+// suites lists every registered Suite; new ones should be added here so
+// the table below exercises them automatically.
+func suites() map[string]*schoco.Suite {
+	return map[string]*schoco.Suite{
+		"Ed25519": schoco.Ed25519(),
+		"BN256G1": schoco.BN256G1(),
+	}
+}
+
+func TestSuiteBasic(t *testing.T) {
+	for name, s := range suites() {
+		s := s
+		t.Run(name, func(t *testing.T) {
+			rootSecretKey, rootPublicKey := s.KeyPair()
+
+			t.Run("Std Schnorr Signature creation and Validation", func(t *testing.T) {
+				signature := s.StdSign(message1, rootSecretKey)
+				if !s.StdVerify(message1, signature, rootPublicKey) {
+					t.Error("Signature is not valid for the provided message and public key")
+				}
+			})
+
+			t.Run("Aggregate", func(t *testing.T) {
+				signature1 := s.StdSign(message1, rootSecretKey)
+
+				partsig1, signature2 := s.Aggregate(message2, signature1)
+
+				setSigR := []kyber.Point{partsig1}
+				setMsg := []string{message2, message1}
+				if !s.Verify(rootPublicKey, setMsg, setSigR, signature2) {
+					t.Error("Validate Aggregate with Verify failed!")
+				}
+			})
+		})
+	}
+}
+
+func TestSuiteByteRoundTrip(t *testing.T) {
+	for name, s := range suites() {
+		s := s
+		t.Run(name, func(t *testing.T) {
+			rootSecretKey, rootPublicKey := s.KeyPair()
+
+			signature1 := s.StdSign(message1, rootSecretKey)
+			_, signature2 := s.Aggregate(message2, signature1)
+
+			t.Run("ToByte/ByteToSignature round-trip", func(t *testing.T) {
+				// signature2.R is PointLen() bytes and signature2.S is
+				// ScalarLen() bytes; on BN256G1 those differ (64 vs 32),
+				// unlike Ed25519 where they happen to match.
+				sigBytes, err := signature2.ToByte()
+				if err != nil {
+					t.Fatalf("ToByte failed: %v", err)
+				}
+				decoded, err := s.ByteToSignature(sigBytes)
+				if err != nil {
+					t.Fatalf("ByteToSignature failed: %v", err)
+				}
+				if !decoded.R.Equal(signature2.R) || !decoded.S.Equal(signature2.S) {
+					t.Error("decoded signature does not match the original")
+				}
+			})
+
+			t.Run("PointToByte/ByteToPoint round-trip", func(t *testing.T) {
+				pointBytes, err := s.PointToByte(rootPublicKey)
+				if err != nil {
+					t.Fatalf("PointToByte failed: %v", err)
+				}
+				decoded, err := s.ByteToPoint(pointBytes)
+				if err != nil {
+					t.Fatalf("ByteToPoint failed: %v", err)
+				}
+				if !decoded.Equal(rootPublicKey) {
+					t.Error("decoded point does not match the original")
+				}
+			})
+		})
+	}
+}
+
+func TestSuiteVerify(t *testing.T) {
+	for name, s := range suites() {
+		s := s
+		t.Run(name, func(t *testing.T) {
+			rootSecretKey, rootPublicKey := s.KeyPair()
+
+			signature1 := s.StdSign(message1, rootSecretKey)
+			aggKey, partSig := signature1.ExtractAggKey()
+			signature2 := s.StdSign(message2, aggKey)
+			partsig2, signature3 := s.Aggregate(message3, signature2)
+
+			t.Run("Validate Std signature with Verify", func(t *testing.T) {
+				if !s.Verify(rootPublicKey, []string{message1}, []kyber.Point{}, signature1) {
+					t.Error("Validate Std signature with Verify failed!")
+				}
+			})
+
+			t.Run("Validate concatenated signature with Verify", func(t *testing.T) {
+				setSigR := []kyber.Point{partsig2, partSig}
+				setMsg := []string{message3, message2, message1}
+				if !s.Verify(rootPublicKey, setMsg, setSigR, signature3) {
+					t.Error("Validate concatenated signature with Verify failed!")
+				}
+			})
+		})
+	}
+}
+
+func TestSuiteCompareAggregation(t *testing.T) {
+	for name, s := range suites() {
+		s := s
+		t.Run(name, func(t *testing.T) {
+			sk, pk := s.KeyPair()
+
+			var msgs []string
+			for i := 0; i < 10; i++ {
+				msgs = append(msgs, message1)
+			}
+
+			aggSig := s.StdSign(msgs[0], sk)
+			aggMsgs := []string{msgs[0]}
+			var aggPartSigs []kyber.Point
+			for i := 1; i < len(msgs); i++ {
+				partSig, newSig := s.Aggregate(msgs[i], aggSig)
+				aggSig = newSig
+				aggPartSigs = append([]kyber.Point{partSig}, aggPartSigs...)
+				aggMsgs = append([]string{msgs[i]}, aggMsgs...)
+			}
+
+			if !s.Verify(pk, aggMsgs, aggPartSigs, aggSig) {
+				t.Error("agg verify failed across 10 hops")
+			}
+		})
+	}
+}